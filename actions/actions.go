@@ -1,37 +1,47 @@
 package actions
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/thenopholo/go_file_manager/chunker"
 	"github.com/thenopholo/go_file_manager/logger"
+	"github.com/thenopholo/go_file_manager/metrics"
 )
 
 type ActionType string
 
 const (
-	ActionBackup  ActionType = "backup"
-	ActionArchive ActionType = "archive"
-	ActionExecute ActionType = "execute"
+	ActionBackup   ActionType = "backup"
+	ActionArchive  ActionType = "archive"
+	ActionExecute  ActionType = "execute"
+	ActionMove     ActionType = "move"
+	ActionCompress ActionType = "compress"
+	ActionDelete   ActionType = "delete"
 )
 
 type Action struct {
-	Type    ActionType
-	Target  string
-	Command string
-	Args    []string
-	Logger  *logger.Logger
+	Type     ActionType
+	Target   string
+	Command  string
+	Args     []string
+	StoreDir string
+	DryRun   bool
+	Logger   *logger.Logger
 }
 
-func NewBackupAction(target string, log *logger.Logger) *Action {
+func NewBackupAction(target, storeDir string, log *logger.Logger) *Action {
 	return &Action{
-		Type:   ActionBackup,
-		Target: target,
-		Logger: log,
+		Type:     ActionBackup,
+		Target:   target,
+		StoreDir: storeDir,
+		Logger:   log,
 	}
 }
 
@@ -52,21 +62,73 @@ func NewExecuteAction(command string, args []string, log *logger.Logger) *Action
 	}
 }
 
-func (a *Action) Execute(filePath string) error {
+func NewMoveAction(target string, log *logger.Logger) *Action {
+	return &Action{
+		Type:   ActionMove,
+		Target: target,
+		Logger: log,
+	}
+}
+
+func NewCompressAction(target string, log *logger.Logger) *Action {
+	return &Action{
+		Type:   ActionCompress,
+		Target: target,
+		Logger: log,
+	}
+}
+
+func NewDeleteAction(log *logger.Logger) *Action {
+	return &Action{
+		Type:   ActionDelete,
+		Logger: log,
+	}
+}
+
+func (a *Action) Execute(filePath string) (err error) {
+	a.Logger.Debugf("action", "executando ação %s para %s", a.Type, filePath)
+
+	start := time.Now()
+	defer func() {
+		result := "success"
+		if err != nil {
+			result = "error"
+		}
+		metrics.ActionDuration.WithLabelValues(string(a.Type), result).Observe(time.Since(start).Seconds())
+	}()
+
+	if a.DryRun {
+		a.Logger.Log(fmt.Sprintf("[dry-run] ação %s seria executada para %s (target=%q)", a.Type, filePath, a.Target))
+		return nil
+	}
+
 	a.Logger.Log(fmt.Sprintf("Executando ação %s para %s", a.Type, filePath))
 
 	switch a.Type {
 	case ActionBackup:
-		return a.executeBackup(filePath)
+		err = a.executeBackup(filePath)
 	case ActionArchive:
-		return a.executeArchive(filePath)
+		err = a.executeArchive(filePath)
 	case ActionExecute:
-		return a.executeCommand(filePath)
+		err = a.executeCommand(filePath)
+	case ActionMove:
+		err = a.executeMove(filePath)
+	case ActionCompress:
+		err = a.executeCompress(filePath)
+	case ActionDelete:
+		err = a.executeDelete(filePath)
 	default:
-		return fmt.Errorf("tipo de ação desconhecido: %s", a.Type)
+		err = fmt.Errorf("tipo de ação desconhecido: %s", a.Type)
 	}
+
+	return err
 }
 
+// executeBackup não copia mais o arquivo inteiro a cada execução. Em vez
+// disso, divide o conteúdo em chunks definidos por conteúdo, grava no
+// content store apenas os chunks ainda não vistos (deduplicação entre
+// backups e entre arquivos) e persiste uma recipe JSON pequena com a lista
+// de hashes necessária para reconstruir o arquivo.
 func (a *Action) executeBackup(filePath string) error {
 	backupDir := a.Target
 	if backupDir == "" {
@@ -77,21 +139,40 @@ func (a *Action) executeBackup(filePath string) error {
 		return fmt.Errorf("erro ao criar diretório de backup: %w", err)
 	}
 
-	fileName := filepath.Base(filePath)
-	timestamp := time.Now().Format("20060102_150405")
-	backupName := fmt.Sprintf("%s_%s", timestamp, fileName)
-	backupPath := filepath.Join(backupDir, backupName)
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("erro ao obter informações do arquivo: %w", err)
+	}
 
-	data, err := os.ReadFile(filePath)
+	file, err := os.Open(filePath)
 	if err != nil {
 		return fmt.Errorf("erro ao ler arquivo: %w", err)
 	}
+	defer file.Close()
+
+	store := chunker.NewStore(a.StoreDir)
+	chunks, err := chunker.New(0, 0, 0).Split(file, store)
+	if err != nil {
+		return fmt.Errorf("erro ao dividir arquivo em chunks: %w", err)
+	}
 
-	if err := os.WriteFile(backupPath, data, 0644); err != nil {
-		return fmt.Errorf("erro ao escrever arquivo de backup: %w", err)
+	recipe := chunker.Recipe{
+		Path:    filePath,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+		Chunks:  chunks,
 	}
 
-	a.Logger.Log(fmt.Sprintf("Backup criado: %s", backupPath))
+	fileName := filepath.Base(filePath)
+	timestamp := time.Now().Format("20060102_150405")
+	recipeName := fmt.Sprintf("%s_%s.recipe.json", timestamp, fileName)
+	recipePath := filepath.Join(backupDir, recipeName)
+
+	if err := chunker.WriteRecipe(recipe, recipePath); err != nil {
+		return fmt.Errorf("erro ao escrever recipe de backup: %w", err)
+	}
+
+	a.Logger.Log(fmt.Sprintf("Backup criado: %s (%d chunks)", recipePath, len(chunks)))
 	return nil
 }
 
@@ -144,3 +225,72 @@ func (a *Action) executeCommand(filePath string) error {
 	a.Logger.Log(fmt.Sprintf("Comando executado com sucesso. Saída: %s", output))
 	return nil
 }
+
+func (a *Action) executeMove(filePath string) error {
+	targetDir := a.Target
+	if targetDir == "" {
+		return fmt.Errorf("ação move requer um target")
+	}
+
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório de destino: %w", err)
+	}
+
+	fileName := filepath.Base(filePath)
+	destPath := filepath.Join(targetDir, fileName)
+
+	if err := os.Rename(filePath, destPath); err != nil {
+		return fmt.Errorf("erro ao mover arquivo para %s: %w", destPath, err)
+	}
+
+	a.Logger.Log(fmt.Sprintf("Arquivo movido para: %s", destPath))
+	return nil
+}
+
+func (a *Action) executeCompress(filePath string) error {
+	targetDir := a.Target
+	if targetDir == "" {
+		targetDir = "compressed"
+	}
+
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório de destino: %w", err)
+	}
+
+	src, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+	defer src.Close()
+
+	fileName := filepath.Base(filePath)
+	destPath := filepath.Join(targetDir, fileName+".gz")
+
+	dest, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo comprimido: %w", err)
+	}
+	defer dest.Close()
+
+	gzWriter := gzip.NewWriter(dest)
+	if _, err := io.Copy(gzWriter, src); err != nil {
+		gzWriter.Close()
+		return fmt.Errorf("erro ao comprimir arquivo: %w", err)
+	}
+
+	if err := gzWriter.Close(); err != nil {
+		return fmt.Errorf("erro ao finalizar compressão: %w", err)
+	}
+
+	a.Logger.Log(fmt.Sprintf("Arquivo comprimido em: %s", destPath))
+	return nil
+}
+
+func (a *Action) executeDelete(filePath string) error {
+	if err := os.Remove(filePath); err != nil {
+		return fmt.Errorf("erro ao remover arquivo: %w", err)
+	}
+
+	a.Logger.Log(fmt.Sprintf("Arquivo removido: %s", filePath))
+	return nil
+}
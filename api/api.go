@@ -0,0 +1,119 @@
+// Package api expõe um servidor HTTP para controle e inspeção em tempo de
+// execução do logger (toggles de debug por facility e consulta ao ring
+// buffer de logs), sem precisar reiniciar o processo de monitoramento.
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/thenopholo/go_file_manager/logger"
+)
+
+type Server struct {
+	addr   string
+	logger *logger.Logger
+	mux    *http.ServeMux
+	server *http.Server
+}
+
+func NewServer(addr string, log *logger.Logger) *Server {
+	s := &Server{
+		addr:   addr,
+		logger: log,
+		mux:    http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/debug/facilities", s.handleFacilities)
+	s.mux.HandleFunc("/logs", s.handleLogs)
+
+	s.server = &http.Server{
+		Addr:    addr,
+		Handler: s.mux,
+	}
+
+	return s
+}
+
+// Mux expõe o multiplexer HTTP interno para que outros pacotes (como
+// metrics) possam registrar rotas adicionais no mesmo servidor, evitando
+// abrir uma porta a mais.
+func (s *Server) Mux() *http.ServeMux {
+	return s.mux
+}
+
+// Start inicia o servidor HTTP em uma goroutine separada e retorna
+// imediatamente, seguindo a mesma convenção de FileMonitor.Start.
+func (s *Server) Start() error {
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.logger.Errorf("Servidor da API de debug encerrado com erro: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *Server) Stop() error {
+	return s.server.Close()
+}
+
+type facilityToggleRequest struct {
+	Facility string `json:"facility"`
+	Enabled  bool   `json:"enabled"`
+}
+
+func (s *Server) handleFacilities(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.logger.Facilities())
+
+	case http.MethodPost:
+		var req facilityToggleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("corpo inválido: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if req.Facility == "" {
+			http.Error(w, "campo 'facility' é obrigatório", http.StatusBadRequest)
+			return
+		}
+
+		s.logger.SetFacility(req.Facility, req.Enabled)
+		writeJSON(w, http.StatusOK, s.logger.Facilities())
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "método não permitido", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "método não permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	since := time.Time{}
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			http.Error(w, "parâmetro 'since' deve ser um timestamp unix", http.StatusBadRequest)
+			return
+		}
+		since = time.Unix(seconds, 0)
+	}
+
+	writeJSON(w, http.StatusOK, s.logger.RecentLogs(since))
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
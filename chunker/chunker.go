@@ -0,0 +1,203 @@
+// Package chunker implementa chunking definido por conteúdo (CDC) usando um
+// hash rolling ao estilo Rabin sobre uma janela deslizante. Ele substitui o
+// hash MD5 de arquivo inteiro usado anteriormente em monitor.calculateHash:
+// ao invés de uma única assinatura por arquivo, cada arquivo é dividido em
+// uma lista ordenada de chunks cujo limite depende apenas do conteúdo local
+// da janela, não do deslocamento absoluto. Isso faz com que inserções ou
+// remoções no meio de um arquivo só alterem os chunks próximos à edição,
+// permitindo diffs por faixa de bytes e backups deduplicados.
+package chunker
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math/bits"
+)
+
+const (
+	// DefaultAvgSize é o tamanho médio de chunk alvo.
+	DefaultAvgSize = 1 * 1024 * 1024
+	// DefaultMinSize é o menor chunk aceito antes de considerar um limite.
+	DefaultMinSize = 512 * 1024
+	// DefaultMaxSize força um limite de chunk mesmo sem um "achado" no hash.
+	DefaultMaxSize = 8 * 1024 * 1024
+
+	// windowSize é o tamanho da janela deslizante usada pelo hash rolling.
+	windowSize = 64
+
+	// rollBase é a base multiplicativa do hash rolling polinomial.
+	rollBase uint64 = 67
+)
+
+// Chunk descreve um pedaço de um arquivo identificado por seu hash forte
+// (SHA-256) e sua posição dentro do arquivo original.
+type Chunk struct {
+	Hash   string `json:"hash"`
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+}
+
+// Chunker divide um stream de bytes em chunks de tamanho variável definidos
+// pelo conteúdo.
+type Chunker struct {
+	avgSize int
+	minSize int
+	maxSize int
+	mask    uint64
+}
+
+// New cria um Chunker com os tamanhos informados, aplicando os defaults do
+// pacote para qualquer valor <= 0.
+func New(avgSize, minSize, maxSize int) *Chunker {
+	if avgSize <= 0 {
+		avgSize = DefaultAvgSize
+	}
+	if minSize <= 0 {
+		minSize = DefaultMinSize
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+
+	return &Chunker{
+		avgSize: avgSize,
+		minSize: minSize,
+		maxSize: maxSize,
+		mask:    maskForAverage(avgSize),
+	}
+}
+
+// maskForAverage deriva uma máscara de bits tal que, em conteúdo aleatório,
+// um limite de chunk (hash&mask == 0) ocorre em média a cada `avg` bytes.
+func maskForAverage(avg int) uint64 {
+	if avg < 2 {
+		avg = 2
+	}
+	n := bits.Len(uint(avg)) - 1
+	if n < 1 {
+		n = 1
+	}
+	return (uint64(1) << uint(n)) - 1
+}
+
+// Split lê todo o conteúdo de r e retorna a lista ordenada de chunks. Se
+// store não for nil, cada chunk novo (ainda não presente no content store) é
+// persistido como efeito colateral — usado pelo fluxo de backup. Quando
+// store é nil, Split só calcula as assinaturas, o caminho mais barato usado
+// pela varredura de detecção de mudanças.
+func (c *Chunker) Split(r io.Reader, store *Store) ([]Chunk, error) {
+	br := newByteReader(r)
+
+	var (
+		chunks    []Chunk
+		window    [windowSize]byte
+		windowPos int
+		filled    int
+		rollHash  uint64
+		power     = basePow(rollBase, windowSize)
+		strong    = sha256.New()
+		buf       bytes.Buffer
+		offset    int64
+	)
+
+	flush := func() error {
+		sum := strong.Sum(nil)
+		hash := hex.EncodeToString(sum)
+		size := int64(buf.Len())
+
+		if store != nil {
+			if err := store.Put(hash, buf.Bytes()); err != nil {
+				return err
+			}
+		}
+
+		chunks = append(chunks, Chunk{Hash: hash, Offset: offset, Size: size})
+
+		offset += size
+		strong = sha256.New()
+		buf.Reset()
+		return nil
+	}
+
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		strong.Write([]byte{b})
+		buf.WriteByte(b)
+
+		old := window[windowPos]
+		window[windowPos] = b
+		windowPos = (windowPos + 1) % windowSize
+
+		rollHash = rollHash*rollBase + uint64(b)
+		if filled < windowSize {
+			filled++
+		} else {
+			rollHash -= uint64(old) * power
+		}
+
+		atBoundary := filled == windowSize && rollHash&c.mask == 0
+		chunkSize := int64(buf.Len())
+
+		if chunkSize >= int64(c.minSize) && (atBoundary || chunkSize >= int64(c.maxSize)) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if buf.Len() > 0 || len(chunks) == 0 {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	return chunks, nil
+}
+
+// basePow calcula base^exp, propositalmente deixando o resultado
+// transbordar (mod 2^64) como o restante da aritmética do hash rolling.
+func basePow(base uint64, exp int) uint64 {
+	result := uint64(1)
+	for i := 0; i < exp; i++ {
+		result *= base
+	}
+	return result
+}
+
+type byteReader struct {
+	r   io.Reader
+	buf []byte
+	pos int
+	n   int
+}
+
+func newByteReader(r io.Reader) *byteReader {
+	return &byteReader{r: r, buf: make([]byte, 64*1024)}
+}
+
+func (b *byteReader) ReadByte() (byte, error) {
+	if b.pos >= b.n {
+		n, err := b.r.Read(b.buf)
+		if n == 0 {
+			if err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		b.n = n
+		b.pos = 0
+	}
+
+	c := b.buf[b.pos]
+	b.pos++
+	return c, nil
+}
@@ -0,0 +1,107 @@
+package chunker
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestSplitEmptyReaderProducesSingleChunk(t *testing.T) {
+	chunks, err := New(0, 0, 0).Split(bytes.NewReader(nil), nil)
+	if err != nil {
+		t.Fatalf("Split retornou erro: %v", err)
+	}
+
+	if len(chunks) != 1 {
+		t.Fatalf("esperava 1 chunk para leitor vazio, obteve %d", len(chunks))
+	}
+
+	if chunks[0].Size != 0 {
+		t.Errorf("esperava chunk de tamanho 0, obteve %d", chunks[0].Size)
+	}
+}
+
+func TestSplitRespectsMinSize(t *testing.T) {
+	c := New(1024, 256, 4096)
+
+	data := make([]byte, 3000)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	chunks, err := c.Split(bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("Split retornou erro: %v", err)
+	}
+
+	for i, chunk := range chunks {
+		if i == len(chunks)-1 {
+			continue
+		}
+		if chunk.Size < 256 {
+			t.Errorf("chunk %d menor que minSize: %d bytes", i, chunk.Size)
+		}
+	}
+}
+
+func TestSplitIsDeterministic(t *testing.T) {
+	data := make([]byte, 5*1024*1024)
+	rand.New(rand.NewSource(42)).Read(data)
+
+	first, err := New(0, 0, 0).Split(bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("Split (1a chamada) retornou erro: %v", err)
+	}
+
+	second, err := New(0, 0, 0).Split(bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("Split (2a chamada) retornou erro: %v", err)
+	}
+
+	if len(first) != len(second) {
+		t.Fatalf("número de chunks diferente entre chamadas: %d vs %d", len(first), len(second))
+	}
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("chunk %d difere entre chamadas: %+v vs %+v", i, first[i], second[i])
+		}
+	}
+}
+
+// TestSplitLocalEditOnlyShiftsNearbyChunks verifica a invariante documentada
+// no pacote: após inserir um único byte perto do início do conteúdo (offset
+// 100), ao menos um chunk posterior à edição deve manter o mesmo hash.
+func TestSplitLocalEditOnlyShiftsNearbyChunks(t *testing.T) {
+	data := make([]byte, 20*1024*1024)
+	rand.New(rand.NewSource(7)).Read(data)
+
+	original, err := New(0, 0, 0).Split(bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("Split original retornou erro: %v", err)
+	}
+
+	edited := make([]byte, 0, len(data)+1)
+	edited = append(edited, data[:100]...)
+	edited = append(edited, 0xAB)
+	edited = append(edited, data[100:]...)
+
+	changed, err := New(0, 0, 0).Split(bytes.NewReader(edited), nil)
+	if err != nil {
+		t.Fatalf("Split editado retornou erro: %v", err)
+	}
+
+	originalHashes := make(map[string]struct{}, len(original))
+	for _, c := range original {
+		originalHashes[c.Hash] = struct{}{}
+	}
+
+	shared := 0
+	for _, c := range changed {
+		if _, ok := originalHashes[c.Hash]; ok {
+			shared++
+		}
+	}
+
+	if shared == 0 {
+		t.Fatalf("esperava que ao menos um chunk distante da edição permanecesse igual, mas nenhum hash foi reaproveitado (original=%d, editado=%d)", len(original), len(changed))
+	}
+}
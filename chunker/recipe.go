@@ -0,0 +1,72 @@
+package chunker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Recipe é o que ActionBackup grava no lugar de uma cópia inteira do
+// arquivo: a lista ordenada de chunks necessária para reconstruí-lo, mais
+// metadados suficientes para identificar a versão original. Os bytes de
+// cada chunk ficam no Store, não na recipe.
+type Recipe struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Chunks  []Chunk   `json:"chunks"`
+}
+
+// WriteRecipe serializa a recipe como JSON indentado no caminho informado.
+func WriteRecipe(recipe Recipe, path string) error {
+	data, err := json.MarshalIndent(recipe, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar recipe: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("erro ao escrever recipe em %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReadRecipe carrega uma recipe previamente gravada por WriteRecipe.
+func ReadRecipe(path string) (Recipe, error) {
+	var recipe Recipe
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return recipe, fmt.Errorf("erro ao ler recipe %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &recipe); err != nil {
+		return recipe, fmt.Errorf("erro ao decodificar recipe %s: %w", path, err)
+	}
+
+	return recipe, nil
+}
+
+// Restore reconstrói um arquivo em outPath a partir de uma recipe, lendo
+// cada chunk do store na ordem em que aparecem.
+func Restore(store *Store, recipe Recipe, outPath string) error {
+	out, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo de destino %s: %w", outPath, err)
+	}
+	defer out.Close()
+
+	for _, chunk := range recipe.Chunks {
+		data, err := store.Get(chunk.Hash)
+		if err != nil {
+			return fmt.Errorf("erro ao restaurar chunk %s de %s: %w", chunk.Hash, recipe.Path, err)
+		}
+
+		if _, err := out.Write(data); err != nil {
+			return fmt.Errorf("erro ao escrever chunk %s em %s: %w", chunk.Hash, outPath, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package chunker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultStoreDir é usado quando nenhum diretório de store é configurado.
+const DefaultStoreDir = ".store"
+
+// Store é um armazenamento endereçado por conteúdo: cada chunk é salvo uma
+// única vez em ".store/<2 primeiros chars do hash>/<restante do hash>".
+type Store struct {
+	root string
+}
+
+func NewStore(root string) *Store {
+	if root == "" {
+		root = DefaultStoreDir
+	}
+	return &Store{root: root}
+}
+
+// Path retorna o caminho no disco para um hash de chunk, sem garantir que
+// ele exista.
+func (s *Store) Path(hash string) string {
+	if len(hash) < 3 {
+		return filepath.Join(s.root, hash)
+	}
+	return filepath.Join(s.root, hash[:2], hash[2:])
+}
+
+func (s *Store) Has(hash string) bool {
+	_, err := os.Stat(s.Path(hash))
+	return err == nil
+}
+
+func (s *Store) Get(hash string) ([]byte, error) {
+	data, err := os.ReadFile(s.Path(hash))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler chunk %s do store: %w", hash, err)
+	}
+	return data, nil
+}
+
+// Put grava data sob hash caso ainda não exista. A escrita é atômica: o
+// conteúdo é gravado em um arquivo temporário no mesmo diretório, sincronizado
+// em disco, e então renomeado para o caminho final; o diretório também é
+// sincronizado para garantir que a entrada do rename sobreviva a uma queda.
+func (s *Store) Put(hash string, data []byte) error {
+	if s.Has(hash) {
+		return nil
+	}
+
+	path := s.Path(hash)
+	dir := filepath.Dir(path)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório do store %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "chunk-*.tmp")
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário do store: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("erro ao escrever chunk %s: %w", hash, err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("erro ao sincronizar chunk %s: %w", hash, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("erro ao fechar arquivo temporário do chunk %s: %w", hash, err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("erro ao mover chunk %s para o store: %w", hash, err)
+	}
+
+	dirFile, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir diretório do store para sincronização: %w", err)
+	}
+	defer dirFile.Close()
+
+	if err := dirFile.Sync(); err != nil {
+		return fmt.Errorf("erro ao sincronizar diretório do store %s: %w", dir, err)
+	}
+
+	return nil
+}
@@ -1,22 +1,40 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/thenopholo/go_file_manager/api"
 	"github.com/thenopholo/go_file_manager/config"
 	"github.com/thenopholo/go_file_manager/logger"
+	"github.com/thenopholo/go_file_manager/metrics"
 	"github.com/thenopholo/go_file_manager/monitor"
+	"github.com/thenopholo/go_file_manager/rules"
 	"github.com/thenopholo/go_file_manager/stats"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "restore" {
+		if err := runRestore(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Erro ao restaurar arquivo: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	dryRun := flag.Bool("dry-run", false, "executa as ações das regras apenas registrando o que faria, sem tocar no sistema de arquivos")
+	flag.Parse()
+
 	fmt.Println("||---------Iniciando sistema de monitoramento de arquivos---------||")
 
 	cfg := config.LoadConfig()
+	if *dryRun {
+		cfg.DryRun = true
+	}
 	fmt.Printf("Monitorando diretório: %s\n", cfg.WatchDir)
 	fmt.Printf("Intervalo de verificação: %s\n", cfg.CheckInterval)
 
@@ -29,7 +47,44 @@ func main() {
 
 	log.Log("||---------Sistema de monitoramento iniciado---------||")
 
+	apiServer := api.NewServer(cfg.APIAddr, log)
+	if err := apiServer.Start(); err != nil {
+		log.Log(fmt.Sprintf("Erro ao iniciar API de debug: %v", err))
+		fmt.Fprintf(os.Stderr, "Erro ao iniciar API de debug: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("API de debug disponível em %s\n", cfg.APIAddr)
+
+	if cfg.MetricsAddr == "" {
+		metrics.RegisterOn(apiServer.Mux())
+		fmt.Printf("Métricas Prometheus disponíveis em %s/metrics\n", cfg.APIAddr)
+	} else {
+		if err := metrics.Start(cfg.MetricsAddr, log); err != nil {
+			log.Log(fmt.Sprintf("Erro ao iniciar endpoint de métricas: %v", err))
+			fmt.Fprintf(os.Stderr, "Erro ao iniciar endpoint de métricas: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Métricas Prometheus disponíveis em %s/metrics\n", cfg.MetricsAddr)
+	}
+
 	fileMonitor := monitor.NewFileMonitor(cfg, log)
+
+	if cfg.AutoAction {
+		if cfg.RulesFile == "" {
+			log.Log("AUTO_ACTION habilitado mas RULES_FILE não foi configurado; automação desativada")
+		} else {
+			engine, err := rules.NewEngine(cfg.RulesFile, cfg.StoreDir, cfg.DryRun, log)
+			if err != nil {
+				log.Log(fmt.Sprintf("Erro ao carregar regras de %s: %v", cfg.RulesFile, err))
+				fmt.Fprintf(os.Stderr, "Erro ao carregar regras de %s: %v\n", cfg.RulesFile, err)
+				os.Exit(1)
+			}
+
+			fileMonitor.RegisterHandler(engine)
+			fmt.Printf("Automação baseada em regras carregada de %s (dry-run=%v)\n", cfg.RulesFile, cfg.DryRun)
+		}
+	}
+
 	if err := fileMonitor.Start(); err != nil {
 		log.Log(fmt.Sprintf("Erro ao iniciar monitoramento: %v", err))
 		fmt.Fprintf(os.Stderr, "Erro ao iniciar monitoramento: %v\n", err)
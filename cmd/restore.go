@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/thenopholo/go_file_manager/chunker"
+	"github.com/thenopholo/go_file_manager/config"
+)
+
+// runRestore implementa o subcomando "restore", que reconstrói um arquivo a
+// partir de uma recipe gravada por ActionBackup e dos chunks correspondentes
+// no content store.
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	recipePath := fs.String("recipe", "", "caminho da recipe JSON gerada pelo backup")
+	outPath := fs.String("out", "", "caminho de saída do arquivo restaurado")
+	storeDir := fs.String("store", "", "diretório do content store (padrão: config.StoreDir)")
+	fs.Parse(args)
+
+	if *recipePath == "" || *outPath == "" {
+		return fmt.Errorf("uso: go_file_manager restore --recipe <arquivo.recipe.json> --out <destino> [--store <dir>]")
+	}
+
+	dir := *storeDir
+	if dir == "" {
+		dir = config.LoadConfig().StoreDir
+	}
+
+	recipe, err := chunker.ReadRecipe(*recipePath)
+	if err != nil {
+		return err
+	}
+
+	store := chunker.NewStore(dir)
+	if err := chunker.Restore(store, recipe, *outPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Arquivo restaurado em %s (%d chunks)\n", *outPath, len(recipe.Chunks))
+	return nil
+}
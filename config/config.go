@@ -8,12 +8,22 @@ import (
 )
 
 type Config struct {
-	WatchDir      string
-	LogDir        string
-	CheckInterval time.Duration
-	MaxLogSize    int64
-	AutoAction    bool
-	IgnoreExts    []string
+	WatchDir       string
+	LogDir         string
+	CheckInterval  time.Duration
+	MaxLogSize     int64
+	AutoAction     bool
+	IgnoreExts     []string
+	Watcher        string
+	APIAddr        string
+	LogBufferSize  int
+	StoreDir       string
+	RulesFile      string
+	DryRun         bool
+	MetricsAddr    string
+	Hashers        int
+	HashCacheSize  int
+	DebounceWindow time.Duration
 }
 
 func LoadConfig() Config {
@@ -24,6 +34,11 @@ func LoadConfig() Config {
   	MaxLogSize:    1024 * 1024 * 10, // 10MB
   	AutoAction:    false,
   	IgnoreExts:    []string{".temp", ".swp"},
+  	Watcher:       "fsnotify",
+  	APIAddr:       ":8090",
+  	LogBufferSize: 250,
+  	StoreDir:      ".store",
+  	HashCacheSize: 4096,
   }
 
   if dir := os.Getenv("WATCH_DIR"); dir != "" {
@@ -54,6 +69,54 @@ func LoadConfig() Config {
     config.IgnoreExts = filepath.SplitList(ignoreExist)
   }
 
+  if watcher := os.Getenv("WATCHER"); watcher == "fsnotify" || watcher == "poll" {
+    config.Watcher = watcher
+  }
+
+  if addr := os.Getenv("API_ADDR"); addr != "" {
+    config.APIAddr = addr
+  }
+
+  if bufSize := os.Getenv("LOG_BUFFER_SIZE"); bufSize != "" {
+    if n, err := strconv.Atoi(bufSize); err == nil && n > 0 {
+      config.LogBufferSize = n
+    }
+  }
+
+  if storeDir := os.Getenv("STORE_DIR"); storeDir != "" {
+    config.StoreDir = storeDir
+  }
+
+  if rulesFile := os.Getenv("RULES_FILE"); rulesFile != "" {
+    config.RulesFile = rulesFile
+  }
+
+  if dryRun := os.Getenv("DRY_RUN"); dryRun == "true" {
+    config.DryRun = true
+  }
+
+  if addr := os.Getenv("METRICS_ADDR"); addr != "" {
+    config.MetricsAddr = addr
+  }
+
+  if hashers := os.Getenv("HASHERS"); hashers != "" {
+    if n, err := strconv.Atoi(hashers); err == nil && n > 0 {
+      config.Hashers = n
+    }
+  }
+
+  if cacheSize := os.Getenv("HASH_CACHE_SIZE"); cacheSize != "" {
+    if n, err := strconv.Atoi(cacheSize); err == nil && n > 0 {
+      config.HashCacheSize = n
+    }
+  }
+
+  if debounce := os.Getenv("DEBOUNCE_WINDOW"); debounce != "" {
+    if millis, err := strconv.Atoi(debounce); err == nil && millis > 0 {
+      config.DebounceWindow = time.Duration(millis) * time.Millisecond
+    }
+  }
+
   ensureDir(config.WatchDir)
   ensureDir(config.LogDir)
 
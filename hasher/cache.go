@@ -0,0 +1,90 @@
+package hasher
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/thenopholo/go_file_manager/chunker"
+)
+
+// DefaultCacheSize é usado quando nenhuma capacidade é informada a NewPool.
+const DefaultCacheSize = 4096
+
+type cacheEntry struct {
+	Hash    string
+	Chunks  []chunker.Chunk
+	Size    int64
+	ModTime time.Time
+}
+
+type cacheNode struct {
+	path  string
+	entry cacheEntry
+}
+
+// cache é uma LRU simples de (path) -> cacheEntry. A entrada só é
+// considerada válida se size e modTime baterem com o estado atual do
+// arquivo; caso contrário é tratada como miss e sobrescrita, o que permite
+// pular o recálculo de chunks para arquivos inalterados entre varreduras
+// sem nunca servir um hash desatualizado.
+type cache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+func newCache(capacity int) *cache {
+	if capacity <= 0 {
+		capacity = DefaultCacheSize
+	}
+
+	return &cache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *cache) get(path string, size int64, modTime time.Time) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[path]
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	node := el.Value.(*cacheNode)
+	if node.entry.Size != size || !node.entry.ModTime.Equal(modTime) {
+		return cacheEntry{}, false
+	}
+
+	c.order.MoveToFront(el)
+	return node.entry, true
+}
+
+func (c *cache) put(path string, size int64, modTime time.Time, hash string, chunks []chunker.Chunk) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := cacheEntry{Hash: hash, Chunks: chunks, Size: size, ModTime: modTime}
+
+	if el, ok := c.items[path]; ok {
+		el.Value.(*cacheNode).entry = entry
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&cacheNode{path: path, entry: entry})
+	c.items[path] = el
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*cacheNode).path)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package hasher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheGetMissesOnSizeOrModTimeChange(t *testing.T) {
+	c := newCache(4)
+	mtime := time.Now()
+
+	c.put("/tmp/a", 100, mtime, "hash-a", nil)
+
+	if _, ok := c.get("/tmp/a", 100, mtime); !ok {
+		t.Fatal("esperava hit para size/mtime inalterados")
+	}
+
+	if _, ok := c.get("/tmp/a", 200, mtime); ok {
+		t.Fatal("esperava miss quando size muda")
+	}
+
+	if _, ok := c.get("/tmp/a", 100, mtime.Add(time.Second)); ok {
+		t.Fatal("esperava miss quando mtime muda")
+	}
+}
+
+func TestCacheEvictsOldestWhenOverCapacity(t *testing.T) {
+	c := newCache(2)
+	mtime := time.Now()
+
+	c.put("/tmp/a", 1, mtime, "hash-a", nil)
+	c.put("/tmp/b", 1, mtime, "hash-b", nil)
+	c.put("/tmp/c", 1, mtime, "hash-c", nil)
+
+	if _, ok := c.get("/tmp/a", 1, mtime); ok {
+		t.Fatal("entrada mais antiga deveria ter sido evitada")
+	}
+
+	if _, ok := c.get("/tmp/b", 1, mtime); !ok {
+		t.Fatal("/tmp/b deveria continuar em cache")
+	}
+
+	if _, ok := c.get("/tmp/c", 1, mtime); !ok {
+		t.Fatal("/tmp/c deveria continuar em cache")
+	}
+}
+
+func TestCacheGetRefreshesRecency(t *testing.T) {
+	c := newCache(2)
+	mtime := time.Now()
+
+	c.put("/tmp/a", 1, mtime, "hash-a", nil)
+	c.put("/tmp/b", 1, mtime, "hash-b", nil)
+
+	// Acessar /tmp/a o torna o mais recente, então /tmp/b deve ser o
+	// próximo a ser evitado, não /tmp/a.
+	if _, ok := c.get("/tmp/a", 1, mtime); !ok {
+		t.Fatal("esperava hit para /tmp/a")
+	}
+
+	c.put("/tmp/c", 1, mtime, "hash-c", nil)
+
+	if _, ok := c.get("/tmp/a", 1, mtime); !ok {
+		t.Fatal("/tmp/a foi acessado recentemente e não deveria ter sido evitado")
+	}
+
+	if _, ok := c.get("/tmp/b", 1, mtime); ok {
+		t.Fatal("/tmp/b deveria ter sido o evitado")
+	}
+}
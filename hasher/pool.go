@@ -0,0 +1,136 @@
+// Package hasher fornece um pool de workers limitado para calcular as
+// assinaturas de conteúdo (chunking via chunker) de arquivos candidatos
+// durante uma varredura, em vez de fazer isso serialmente na goroutine da
+// varredura. Resultados recentes ficam em uma LRU por (path, size, mtime)
+// para que arquivos inalterados entre varreduras nunca sejam reprocessados.
+package hasher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/thenopholo/go_file_manager/chunker"
+)
+
+// Job descreve um arquivo candidato a ter sua assinatura recalculada.
+type Job struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Result é o que Pool.HashAll retorna para cada Job submetido.
+type Result struct {
+	Path   string
+	Hash   string
+	Chunks []chunker.Chunk
+	Err    error
+}
+
+// Pool é um conjunto fixo de workers que consomem Jobs de uma fila limitada,
+// aplicando backpressure: se os workers não derem conta, a submissão de
+// novos jobs bloqueia em vez de empilhar trabalho ilimitado em memória.
+type Pool struct {
+	workers int
+	cache   *cache
+}
+
+// NewPool cria um Pool com `workers` goroutines (workers <= 0 usa
+// ResolveWorkers(0)) e uma LRU de `cacheSize` entradas (<=0 usa
+// DefaultCacheSize).
+func NewPool(workers, cacheSize int) *Pool {
+	if workers <= 0 {
+		workers = ResolveWorkers(0)
+	}
+
+	return &Pool{
+		workers: workers,
+		cache:   newCache(cacheSize),
+	}
+}
+
+// HashAll processa todos os jobs e retorna um Result por Job.Path. Para de
+// submeter trabalho novo assim que ctx é cancelado (tipicamente amarrado ao
+// stopChan do FileMonitor), mas sempre drena os workers em andamento antes
+// de retornar.
+func (p *Pool) HashAll(ctx context.Context, jobs []Job) map[string]Result {
+	queueSize := p.workers * 4
+	if queueSize < 1 {
+		queueSize = 1
+	}
+
+	jobCh := make(chan Job, queueSize)
+	resultCh := make(chan Result, queueSize)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				resultCh <- p.process(job)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobCh)
+		for _, job := range jobs {
+			select {
+			case jobCh <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	results := make(map[string]Result, len(jobs))
+	for res := range resultCh {
+		results[res.Path] = res
+	}
+
+	return results
+}
+
+func (p *Pool) process(job Job) Result {
+	if entry, ok := p.cache.get(job.Path, job.Size, job.ModTime); ok {
+		return Result{Path: job.Path, Hash: entry.Hash, Chunks: entry.Chunks}
+	}
+
+	file, err := os.Open(job.Path)
+	if err != nil {
+		return Result{Path: job.Path, Err: err}
+	}
+	defer file.Close()
+
+	chunks, err := chunker.New(0, 0, 0).Split(file, nil)
+	if err != nil {
+		return Result{Path: job.Path, Err: err}
+	}
+
+	hash := combineChunkHashes(chunks)
+	p.cache.put(job.Path, job.Size, job.ModTime, hash, chunks)
+
+	return Result{Path: job.Path, Hash: hash, Chunks: chunks}
+}
+
+// combineChunkHashes reduz a lista ordenada de hashes de chunk a uma única
+// assinatura de arquivo inteiro, usada para a checagem rápida de igualdade
+// em FileInfo.Hash.
+func combineChunkHashes(chunks []chunker.Chunk) string {
+	h := sha256.New()
+	for _, c := range chunks {
+		io.WriteString(h, c.Hash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,50 @@
+package hasher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPoolHashAllPopulatesResultsAndReusesCache(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(path, []byte("conteudo de teste"), 0o644); err != nil {
+		t.Fatalf("falha ao escrever arquivo de teste: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("falha ao obter stat: %v", err)
+	}
+
+	pool := NewPool(2, 16)
+	job := Job{Path: path, Size: info.Size(), ModTime: info.ModTime()}
+
+	first := pool.HashAll(context.Background(), []Job{job})
+	res, ok := first[path]
+	if !ok {
+		t.Fatalf("esperava resultado para %s", path)
+	}
+	if res.Err != nil {
+		t.Fatalf("HashAll retornou erro: %v", res.Err)
+	}
+	if res.Hash == "" {
+		t.Fatal("esperava hash não vazio")
+	}
+	if len(res.Chunks) == 0 {
+		t.Fatal("esperava ao menos um chunk")
+	}
+
+	// Uma segunda chamada com o mesmo size/mtime deve vir do cache e
+	// retornar exatamente o mesmo hash/chunks.
+	second := pool.HashAll(context.Background(), []Job{job})
+	res2 := second[path]
+	if res2.Hash != res.Hash {
+		t.Errorf("hash divergiu entre chamadas com cache: %q vs %q", res.Hash, res2.Hash)
+	}
+	if len(res2.Chunks) != len(res.Chunks) {
+		t.Errorf("chunks divergiram entre chamadas com cache: %d vs %d", len(res2.Chunks), len(res.Chunks))
+	}
+}
@@ -0,0 +1,37 @@
+package hasher
+
+import "runtime"
+
+// DefaultWorkers recomenda um número de workers de hashing para o SO atual.
+// Em Linux, onde I/O paralelo em discos de uso comum costuma escalar bem,
+// usamos todos os núcleos disponíveis. Em Windows e Darwin, ambientes
+// tipicamente interativos, o padrão é conservador (1) para não competir por
+// I/O com o resto do sistema; quem quiser mais pode configurar
+// config.Config.Hashers explicitamente.
+func DefaultWorkers() int {
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		return 1
+	default:
+		return runtime.NumCPU()
+	}
+}
+
+// ResolveWorkers aplica um override explícito (se > 0) sobre DefaultWorkers,
+// sempre limitado a runtime.NumCPU() e a no mínimo 1.
+func ResolveWorkers(override int) int {
+	workers := DefaultWorkers()
+	if override > 0 {
+		workers = override
+	}
+
+	if cpu := runtime.NumCPU(); workers > cpu {
+		workers = cpu
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	return workers
+}
@@ -10,16 +10,50 @@ import (
 	"github.com/thenopholo/go_file_manager/config"
 )
 
+// Level identifica a severidade de uma entrada de log.
+type Level string
+
+const (
+	LevelDebug Level = "DEBUG"
+	LevelInfo  Level = "INFO"
+	LevelWarn  Level = "WARN"
+	LevelError Level = "ERROR"
+)
+
+// Entry é uma linha de log mantida no ring buffer em memória, exposta via
+// API para inspeção em campo sem precisar abrir o arquivo de log.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Level    Level     `json:"level"`
+	Facility string    `json:"facility,omitempty"`
+	Message  string    `json:"message"`
+}
+
 type Logger struct {
 	config      config.Config
 	file        *os.File
 	mu          sync.Mutex
 	currentSize int64
+
+	facilitiesMu sync.RWMutex
+	facilities   map[string]bool
+
+	ringMu    sync.Mutex
+	ring      []Entry
+	ringNext  int
+	ringCount int
 }
 
 func NewLogger(cfg config.Config) (*Logger, error) {
+	bufSize := cfg.LogBufferSize
+	if bufSize <= 0 {
+		bufSize = 250
+	}
+
 	logger := &Logger{
-		config: cfg,
+		config:     cfg,
+		facilities: make(map[string]bool),
+		ring:       make([]Entry, bufSize),
 	}
 
 	if err := logger.openLogFile(); err != nil {
@@ -57,10 +91,110 @@ func (l *Logger) openLogFile() error {
 }
 
 func (l *Logger) Log(message string) error {
+	return l.write(LevelInfo, "", message)
+}
+
+func (l *Logger) LogEvent(event, path string) error {
+	message := fmt.Sprintf("EVENTO: %s | Arquivo: %s", event, path)
+	return l.Log(message)
+}
+
+// Debugf registra uma mensagem de depuração associada a uma facility
+// (ex.: "monitor", "hash", "action"). A formatação só é executada se a
+// facility estiver habilitada, então chamadas em caminhos quentes do
+// código (loops de varredura, hashing) têm custo desprezível quando
+// desligadas.
+func (l *Logger) Debugf(facility, format string, args ...interface{}) error {
+	if !l.ShouldDebug(facility) {
+		return nil
+	}
+	return l.write(LevelDebug, facility, fmt.Sprintf(format, args...))
+}
+
+func (l *Logger) Infof(format string, args ...interface{}) error {
+	return l.write(LevelInfo, "", fmt.Sprintf(format, args...))
+}
+
+func (l *Logger) Warnf(format string, args ...interface{}) error {
+	return l.write(LevelWarn, "", fmt.Sprintf(format, args...))
+}
+
+func (l *Logger) Errorf(format string, args ...interface{}) error {
+	return l.write(LevelError, "", fmt.Sprintf(format, args...))
+}
+
+// ShouldDebug permite que chamadores evitem montar argumentos caros quando a
+// facility está desligada: `if log.ShouldDebug("hash") { ... }`.
+func (l *Logger) ShouldDebug(facility string) bool {
+	l.facilitiesMu.RLock()
+	defer l.facilitiesMu.RUnlock()
+	return l.facilities[facility]
+}
+
+// SetFacility habilita ou desabilita logs de debug para uma facility em
+// tempo real, sem reiniciar o processo.
+func (l *Logger) SetFacility(facility string, enabled bool) {
+	l.facilitiesMu.Lock()
+	defer l.facilitiesMu.Unlock()
+	l.facilities[facility] = enabled
+}
+
+// Facilities retorna uma cópia do estado atual de todas as facilities
+// conhecidas.
+func (l *Logger) Facilities() map[string]bool {
+	l.facilitiesMu.RLock()
+	defer l.facilitiesMu.RUnlock()
+
+	out := make(map[string]bool, len(l.facilities))
+	for k, v := range l.facilities {
+		out[k] = v
+	}
+	return out
+}
+
+// RecentLogs retorna as entradas do ring buffer com Time após `since`, em
+// ordem cronológica.
+func (l *Logger) RecentLogs(since time.Time) []Entry {
+	l.ringMu.Lock()
+	defer l.ringMu.Unlock()
+
+	ordered := make([]Entry, 0, l.ringCount)
+	size := len(l.ring)
+
+	start := l.ringNext - l.ringCount
+	if start < 0 {
+		start += size
+	}
+
+	for i := 0; i < l.ringCount; i++ {
+		entry := l.ring[(start+i)%size]
+		if entry.Time.After(since) {
+			ordered = append(ordered, entry)
+		}
+	}
+
+	return ordered
+}
+
+func (l *Logger) write(level Level, facility, message string) error {
+	entry := Entry{
+		Time:     time.Now(),
+		Level:    level,
+		Facility: facility,
+		Message:  message,
+	}
+
+	l.appendToRing(entry)
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	formattedMsg := fmt.Sprintf("[%s] %s\n", time.Now().Format("2006-01-02 15:04:05"), message)
+	prefix := string(level)
+	if facility != "" {
+		prefix = fmt.Sprintf("%s[%s]", level, facility)
+	}
+
+	formattedMsg := fmt.Sprintf("[%s] %s: %s\n", entry.Time.Format("2006-01-02 15:04:05"), prefix, message)
 
 	bytesWritten, err := l.file.WriteString(formattedMsg)
 	if err != nil {
@@ -76,9 +210,20 @@ func (l *Logger) Log(message string) error {
 	return nil
 }
 
-func (l *Logger) LogEvent(event, path string) error {
-	message := fmt.Sprintf("EVENTO: %s | Arquivo: %s", event, path)
-	return l.Log(message)
+func (l *Logger) appendToRing(entry Entry) {
+	l.ringMu.Lock()
+	defer l.ringMu.Unlock()
+
+	size := len(l.ring)
+	if size == 0 {
+		return
+	}
+
+	l.ring[l.ringNext] = entry
+	l.ringNext = (l.ringNext + 1) % size
+	if l.ringCount < size {
+		l.ringCount++
+	}
 }
 
 func (l *Logger) Close() error {
@@ -0,0 +1,76 @@
+// Package metrics expõe as métricas do sistema de monitoramento em formato
+// Prometheus. Os coletores são variáveis de pacote registradas uma única
+// vez em init, e são incrementados diretamente pelos pacotes monitor e
+// actions nos pontos de instrumentação (varredura, detecção de mudanças e
+// execução de ações).
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/thenopholo/go_file_manager/logger"
+)
+
+var (
+	EventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "filemonitor_events_total",
+		Help: "Total de eventos de arquivo detectados, por tipo (create, modify, delete).",
+	}, []string{"type"})
+
+	FilesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "filemonitor_files",
+		Help: "Número de arquivos atualmente monitorados.",
+	})
+
+	BytesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "filemonitor_bytes",
+		Help: "Tamanho total em bytes dos arquivos atualmente monitorados.",
+	})
+
+	ScanDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "filemonitor_scan_duration_seconds",
+		Help:    "Duração das varreduras de diretório.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	ActionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "filemonitor_action_duration_seconds",
+		Help:    "Duração da execução de ações, por tipo e resultado (success, error).",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"type", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(EventsTotal, FilesGauge, BytesGauge, ScanDuration, ActionDuration)
+}
+
+// Handler retorna o http.Handler padrão do Prometheus para expor em /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// RegisterOn registra o endpoint /metrics em um mux HTTP já existente,
+// permitindo reaproveitar o servidor da API de debug em vez de abrir uma
+// segunda porta.
+func RegisterOn(mux *http.ServeMux) {
+	mux.Handle("/metrics", Handler())
+}
+
+// Start inicia um listener HTTP dedicado servindo apenas /metrics em addr,
+// seguindo a mesma convenção de api.Server.Start: roda em uma goroutine
+// separada e loga uma falha de bind em vez de descartá-la silenciosamente.
+// Usado quando config.Config.MetricsAddr é definido explicitamente.
+func Start(addr string, log *logger.Logger) error {
+	mux := http.NewServeMux()
+	RegisterOn(mux)
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil && err != http.ErrServerClosed {
+			log.Errorf("Servidor de métricas encerrado com erro: %v", err)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package monitor
+
+import (
+	"sync"
+	"time"
+)
+
+// Handler recebe notificações de eventos detectados pelo FileMonitor.
+// Implementações típicas: logging, ações automáticas e estatísticas.
+type Handler interface {
+	OnCreate(info FileInfo)
+	OnModify(old, new FileInfo)
+	OnDelete(info FileInfo)
+}
+
+// ChainHandler distribui cada evento para uma lista ordenada de handlers.
+type ChainHandler struct {
+	handlers []Handler
+}
+
+func NewChainHandler(handlers ...Handler) *ChainHandler {
+	return &ChainHandler{handlers: handlers}
+}
+
+func (c *ChainHandler) Add(h Handler) {
+	c.handlers = append(c.handlers, h)
+}
+
+func (c *ChainHandler) OnCreate(info FileInfo) {
+	for _, h := range c.handlers {
+		h.OnCreate(info)
+	}
+}
+
+func (c *ChainHandler) OnModify(old, new FileInfo) {
+	for _, h := range c.handlers {
+		h.OnModify(old, new)
+	}
+}
+
+func (c *ChainHandler) OnDelete(info FileInfo) {
+	for _, h := range c.handlers {
+		h.OnDelete(info)
+	}
+}
+
+// DebounceHandler encapsula um Handler e atrasa chamadas de OnModify para o
+// mesmo arquivo, repassando apenas a última versão após o evento ficar
+// "quieto" por `window`. Isso evita disparos repetidos quando um editor
+// reescreve um arquivo em várias gravações curtas. OnCreate e OnDelete são
+// repassados imediatamente. FileMonitor habilita isso automaticamente quando
+// config.Config.DebounceWindow é maior que zero.
+type DebounceHandler struct {
+	next   Handler
+	window time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*debouncedModify
+}
+
+type debouncedModify struct {
+	old   FileInfo
+	new   FileInfo
+	timer *time.Timer
+}
+
+func NewDebounceHandler(next Handler, window time.Duration) *DebounceHandler {
+	return &DebounceHandler{
+		next:    next,
+		window:  window,
+		pending: make(map[string]*debouncedModify),
+	}
+}
+
+func (d *DebounceHandler) OnCreate(info FileInfo) {
+	d.next.OnCreate(info)
+}
+
+func (d *DebounceHandler) OnModify(old, new FileInfo) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if entry, exists := d.pending[new.Path]; exists {
+		entry.new = new
+		entry.timer.Reset(d.window)
+		return
+	}
+
+	entry := &debouncedModify{old: old, new: new}
+	entry.timer = time.AfterFunc(d.window, func() {
+		d.flush(new.Path)
+	})
+	d.pending[new.Path] = entry
+}
+
+func (d *DebounceHandler) OnDelete(info FileInfo) {
+	d.mu.Lock()
+	if entry, exists := d.pending[info.Path]; exists {
+		entry.timer.Stop()
+		delete(d.pending, info.Path)
+	}
+	d.mu.Unlock()
+
+	d.next.OnDelete(info)
+}
+
+func (d *DebounceHandler) flush(path string) {
+	d.mu.Lock()
+	entry, exists := d.pending[path]
+	if exists {
+		delete(d.pending, path)
+	}
+	d.mu.Unlock()
+
+	if exists {
+		d.next.OnModify(entry.old, entry.new)
+	}
+}
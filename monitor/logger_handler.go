@@ -0,0 +1,25 @@
+package monitor
+
+import "github.com/thenopholo/go_file_manager/logger"
+
+// loggerHandler adapta o logger.Logger para a interface Handler, preservando
+// o comportamento histórico de registrar cada evento como uma linha de log.
+type loggerHandler struct {
+	logger *logger.Logger
+}
+
+func newLoggerHandler(log *logger.Logger) *loggerHandler {
+	return &loggerHandler{logger: log}
+}
+
+func (h *loggerHandler) OnCreate(info FileInfo) {
+	h.logger.LogEvent("CRIADO", info.Path)
+}
+
+func (h *loggerHandler) OnModify(old, new FileInfo) {
+	h.logger.LogEvent("MODIFICADO", new.Path)
+}
+
+func (h *loggerHandler) OnDelete(info FileInfo) {
+	h.logger.LogEvent("EXCLUÍDO", info.Path)
+}
@@ -1,17 +1,18 @@
 package monitor
 
 import (
-	"crypto/md5"
-	"encoding/hex"
+	"context"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/thenopholo/go_file_manager/chunker"
 	"github.com/thenopholo/go_file_manager/config"
+	"github.com/thenopholo/go_file_manager/hasher"
 	"github.com/thenopholo/go_file_manager/logger"
+	"github.com/thenopholo/go_file_manager/metrics"
 )
 
 type FileInfo struct {
@@ -19,12 +20,16 @@ type FileInfo struct {
 	Size    int64
 	ModTime time.Time
 	Hash    string
+	Chunks  []chunker.Chunk
 	IsDir   bool
 }
 
 type FileMonitor struct {
 	config   config.Config
 	logger   *logger.Logger
+	chain    *ChainHandler
+	handler  Handler
+	hasher   *hasher.Pool
 	files    map[string]FileInfo
 	mutex    sync.RWMutex
 	isRunnig bool
@@ -32,12 +37,32 @@ type FileMonitor struct {
 }
 
 func NewFileMonitor(cfg config.Config, log *logger.Logger) *FileMonitor {
-	return &FileMonitor{
+	chain := NewChainHandler()
+	chain.Add(newLoggerHandler(log))
+
+	var handler Handler = chain
+	if cfg.DebounceWindow > 0 {
+		handler = NewDebounceHandler(chain, cfg.DebounceWindow)
+	}
+
+	m := &FileMonitor{
 		config:   cfg,
 		logger:   log,
+		chain:    chain,
+		handler:  handler,
+		hasher:   hasher.NewPool(cfg.Hashers, cfg.HashCacheSize),
 		files:    make(map[string]FileInfo),
 		stopChan: make(chan struct{}),
 	}
+
+	return m
+}
+
+// RegisterHandler adiciona um Handler à cadeia de notificação de eventos.
+// Deve ser chamado antes de Start. Fica sujeito ao mesmo debounce de
+// config.Config.DebounceWindow que o restante da cadeia.
+func (m *FileMonitor) RegisterHandler(h Handler) {
+	m.chain.Add(h)
 }
 
 func (m *FileMonitor) Start() error {
@@ -57,7 +82,23 @@ func (m *FileMonitor) Start() error {
 		return err
 	}
 
-	go m.monitorLoop()
+	switch m.config.Watcher {
+	case "fsnotify":
+		w, err := newFsnotifyWatcher(m)
+		if err != nil {
+			m.logger.Log(fmt.Sprintf("Falha ao iniciar fsnotify, voltando para o modo poll: %v", err))
+			go m.monitorLoop()
+			break
+		}
+
+		go func() {
+			if err := w.run(); err != nil {
+				m.logger.Log(fmt.Sprintf("Watcher fsnotify encerrado com erro: %v", err))
+			}
+		}()
+	default:
+		go m.monitorLoop()
+	}
 
 	return nil
 }
@@ -96,23 +137,35 @@ func (m *FileMonitor) shouldIgnore(path string) bool {
 	return false
 }
 
-func calculateHash(path string) (string, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return "", err
+// changedRanges retorna as faixas de bytes dos chunks que existem em newChunks
+// mas não em oldChunks (por hash), ou seja, o conteúdo que de fato mudou
+// entre duas versões de um arquivo.
+func changedRanges(oldChunks, newChunks []chunker.Chunk) []chunker.Chunk {
+	previous := make(map[string]struct{}, len(oldChunks))
+	for _, c := range oldChunks {
+		previous[c.Hash] = struct{}{}
 	}
-	defer file.Close()
 
-	hash := md5.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return "", err
+	var changed []chunker.Chunk
+	for _, c := range newChunks {
+		if _, exists := previous[c.Hash]; !exists {
+			changed = append(changed, c)
+		}
 	}
 
-	hashInBytes := hash.Sum(nil)
-	return hex.EncodeToString(hashInBytes), nil
+	return changed
 }
 
+// scanDirectory é o backend "poll": percorre toda a WatchDir a cada
+// CheckInterval. É usado quando config.Config.Watcher não é "fsnotify" (ex.:
+// "poll") e como fallback quando o backend fsnotify não pode ser iniciado.
 func (m *FileMonitor) scanDirectory() error {
+	m.logger.Debugf("monitor", "iniciando varredura de %s", m.config.WatchDir)
+	start := time.Now()
+	defer func() {
+		metrics.ScanDuration.Observe(time.Since(start).Seconds())
+	}()
+
 	m.mutex.Lock()
 	oldFiles := make(map[string]FileInfo, len(m.files))
 	for k, v := range m.files {
@@ -151,45 +204,209 @@ func (m *FileMonitor) scanDirectory() error {
 		return err
 	}
 
+	m.hashChangedFiles(oldFiles, currentFiles)
 	m.detectChanges(oldFiles, currentFiles)
 
 	m.mutex.Lock()
 	m.files = currentFiles
 	m.mutex.Unlock()
 
+	m.updateGaugeMetrics()
+
 	return nil
 }
 
+// hashChangedFiles submete ao pool de hashing apenas os arquivos novos ou
+// cujo tamanho/mtime mudou desde a última varredura, e copia Hash/Chunks de
+// oldFiles para os demais, que permanecem inalterados. Isso evita reler e
+// refazer o chunking de todo o conteúdo monitorado a cada varredura.
+func (m *FileMonitor) hashChangedFiles(oldFiles, currentFiles map[string]FileInfo) {
+	jobs := make([]hasher.Job, 0, len(currentFiles))
+
+	for path, info := range currentFiles {
+		if info.IsDir {
+			continue
+		}
+
+		old, existed := oldFiles[path]
+		if existed && old.Size == info.Size && old.ModTime.Equal(info.ModTime) {
+			info.Hash = old.Hash
+			info.Chunks = old.Chunks
+			currentFiles[path] = info
+			continue
+		}
+
+		jobs = append(jobs, hasher.Job{Path: path, Size: info.Size, ModTime: info.ModTime})
+	}
+
+	if len(jobs) == 0 {
+		return
+	}
+
+	ctx, cancel := contextFromStopChan(m.stopChan)
+	defer cancel()
+
+	results := m.hasher.HashAll(ctx, jobs)
+	for path, res := range results {
+		if res.Err != nil {
+			m.logger.Debugf("monitor", "falha ao calcular hash de %s: %v", path, res.Err)
+			continue
+		}
+
+		info := currentFiles[path]
+		info.Hash = res.Hash
+		info.Chunks = res.Chunks
+		currentFiles[path] = info
+	}
+}
+
+// contextFromStopChan cria um context.Context cancelado assim que stop for
+// fechado, permitindo que HashAll pare de submeter trabalho novo quando
+// FileMonitor.Stop é chamado no meio de uma varredura.
+func contextFromStopChan(stop chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
 func (m *FileMonitor) detectChanges(oldFiles, newFiles map[string]FileInfo) {
-  for path, newInfo := range newFiles{
-    oldInfo, exists := oldFiles[path]
+	for path, newInfo := range newFiles {
+		oldInfo, exists := oldFiles[path]
+
+		if !exists {
+			metrics.EventsTotal.WithLabelValues("create").Inc()
+			m.handler.OnCreate(newInfo)
+		} else if !newInfo.IsDir && (newInfo.Size != oldInfo.Size || !newInfo.ModTime.Equal(oldInfo.ModTime) || (newInfo.Hash != "" && oldInfo.Hash != "" && newInfo.Hash != oldInfo.Hash)) {
+			if len(oldInfo.Chunks) > 0 && len(newInfo.Chunks) > 0 {
+				for _, c := range changedRanges(oldInfo.Chunks, newInfo.Chunks) {
+					m.logger.Debugf("monitor", "%s: bytes [%d-%d) mudaram (chunk %s)", path, c.Offset, c.Offset+c.Size, c.Hash[:12])
+				}
+			}
+			metrics.EventsTotal.WithLabelValues("modify").Inc()
+			m.handler.OnModify(oldInfo, newInfo)
+		}
+	}
 
-    if !exists {
-      m.logger.LogEvent("CRIADO", path)
-    } else if !newInfo.IsDir && (newInfo.Size != oldInfo.Size || !newInfo.ModTime.Equal(oldInfo.ModTime) || (newInfo.Hash != "" && oldInfo.Hash != "" && newInfo.Hash != oldInfo.Hash)){
-      m.logger.LogEvent("MODIFICADO", path)
-    }
+	for path, oldInfo := range oldFiles {
+		if _, exists := newFiles[path]; !exists {
+			metrics.EventsTotal.WithLabelValues("delete").Inc()
+			m.handler.OnDelete(oldInfo)
+		}
+	}
+}
 
-    for path := range oldFiles {
-      if _, exists := newFiles[path]; !exists {
-        m.logger.LogEvent("EXCLUÍDO", path)
-      }
-    }
-  }
+// upsertFile atualiza o estado interno de um único arquivo/diretório e
+// dispara o handler apropriado. É usado pelo backend fsnotify, que recebe
+// eventos individuais em vez de varreduras completas.
+func (m *FileMonitor) upsertFile(info FileInfo) {
+	if !info.IsDir {
+		info = m.hashFile(info)
+	}
+
+	m.mutex.Lock()
+	oldInfo, existed := m.files[info.Path]
+	m.files[info.Path] = info
+	m.mutex.Unlock()
+
+	m.updateGaugeMetrics()
+
+	if !existed {
+		metrics.EventsTotal.WithLabelValues("create").Inc()
+		m.handler.OnCreate(info)
+		return
+	}
+
+	if !info.IsDir && (info.Size != oldInfo.Size || !info.ModTime.Equal(oldInfo.ModTime) || (info.Hash != "" && oldInfo.Hash != "" && info.Hash != oldInfo.Hash)) {
+		if len(oldInfo.Chunks) > 0 && len(info.Chunks) > 0 {
+			for _, c := range changedRanges(oldInfo.Chunks, info.Chunks) {
+				m.logger.Debugf("monitor", "%s: bytes [%d-%d) mudaram (chunk %s)", info.Path, c.Offset, c.Offset+c.Size, c.Hash[:12])
+			}
+		}
+		metrics.EventsTotal.WithLabelValues("modify").Inc()
+		m.handler.OnModify(oldInfo, info)
+	}
+}
+
+// hashFile calcula Hash/Chunks de um único arquivo através do mesmo pool de
+// hashing usado pela varredura em modo poll (compartilhando a LRU por
+// path/size/mtime), para que o backend fsnotify também mantenha esses
+// campos populados em regime contínuo, não só na varredura inicial.
+func (m *FileMonitor) hashFile(info FileInfo) FileInfo {
+	ctx, cancel := contextFromStopChan(m.stopChan)
+	defer cancel()
+
+	results := m.hasher.HashAll(ctx, []hasher.Job{{Path: info.Path, Size: info.Size, ModTime: info.ModTime}})
+
+	res, ok := results[info.Path]
+	if !ok {
+		return info
+	}
+	if res.Err != nil {
+		m.logger.Debugf("monitor", "falha ao calcular hash de %s: %v", info.Path, res.Err)
+		return info
+	}
+
+	info.Hash = res.Hash
+	info.Chunks = res.Chunks
+	return info
+}
+
+// removeFile remove o caminho do estado interno e dispara OnDelete, caso o
+// caminho fosse conhecido.
+func (m *FileMonitor) removeFile(path string) {
+	m.mutex.Lock()
+	info, existed := m.files[path]
+	delete(m.files, path)
+	m.mutex.Unlock()
+
+	if existed {
+		m.updateGaugeMetrics()
+		metrics.EventsTotal.WithLabelValues("delete").Inc()
+		m.handler.OnDelete(info)
+	}
+}
+
+// updateGaugeMetrics sincroniza os gauges Prometheus de contagem e tamanho
+// total com o estado atual de m.files.
+func (m *FileMonitor) updateGaugeMetrics() {
+	metrics.FilesGauge.Set(float64(m.GetFileCount()))
+	metrics.BytesGauge.Set(float64(m.GetTotalSize()))
+}
+
+// Files retorna uma cópia do estado atual de todos os arquivos e diretórios
+// monitorados, usada por stats.GenerateStats para as quebras por extensão,
+// diretório, dono e idade.
+func (m *FileMonitor) Files() []FileInfo {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	out := make([]FileInfo, 0, len(m.files))
+	for _, info := range m.files {
+		out = append(out, info)
+	}
+	return out
 }
 
 func (m *FileMonitor) GetFileCount() int {
-  m.mutex.RLock()
-  defer m.mutex.RUnlock()
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
 
-  count := 0
-  for _, info := range m.files{
-    if !info.IsDir {
-      count ++
-    }
-  }
+	count := 0
+	for _, info := range m.files {
+		if !info.IsDir {
+			count++
+		}
+	}
 
-  return count
+	return count
 }
 
 func (m *FileMonitor) GetTotalSize() int64 {
@@ -204,4 +421,4 @@ func (m *FileMonitor) GetTotalSize() int64 {
 	}
 
 	return total
-}
\ No newline at end of file
+}
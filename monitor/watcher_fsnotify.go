@@ -0,0 +1,140 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// fsnotifyWatcher é o backend orientado a eventos selecionado via
+// config.Config.Watcher == "fsnotify". Registra recursivamente todos os
+// subdiretórios de WatchDir, adiciona novos diretórios assim que são
+// criados (junto com todo o conteúdo pré-existente dentro deles) e remove o
+// registro de diretórios apagados.
+type fsnotifyWatcher struct {
+	monitor *FileMonitor
+	watcher *fsnotify.Watcher
+}
+
+func newFsnotifyWatcher(m *FileMonitor) (*fsnotifyWatcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar watcher fsnotify: %w", err)
+	}
+
+	fw := &fsnotifyWatcher{monitor: m, watcher: w}
+
+	if err := fw.addRecursive(m.config.WatchDir); err != nil {
+		w.Close()
+		return nil, fmt.Errorf("erro ao registrar diretórios em %s: %w", m.config.WatchDir, err)
+	}
+
+	return fw, nil
+}
+
+// addRecursive registra o watcher em root e em todos os seus subdiretórios,
+// e também publica via upsertFile tudo o que já existir dentro da árvore
+// (arquivos e diretórios). Isso é necessário tanto na inicialização quanto
+// quando um diretório inteiro aparece de uma vez em WatchDir (rsync, unzip,
+// git checkout, ou um os.Rename vindo de fora da árvore monitorada): sem
+// isso, só o próprio diretório seria notificado, e seu conteúdo
+// pré-existente ficaria invisível até algo escrever nele diretamente.
+func (fw *fsnotifyWatcher) addRecursive(root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path == fw.monitor.config.LogDir {
+				return filepath.SkipDir
+			}
+
+			if err := fw.watcher.Add(path); err != nil {
+				return err
+			}
+		}
+
+		fw.monitor.upsertFile(FileInfo{
+			Path:    path,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+			IsDir:   info.IsDir(),
+		})
+
+		return nil
+	})
+}
+
+func (fw *fsnotifyWatcher) run() error {
+	defer fw.watcher.Close()
+
+	for {
+		select {
+		case event, ok := <-fw.watcher.Events:
+			if !ok {
+				return nil
+			}
+			fw.handleEvent(event)
+		case err, ok := <-fw.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fw.monitor.logger.Log(fmt.Sprintf("Erro no watcher fsnotify: %v", err))
+		case <-fw.monitor.stopChan:
+			return nil
+		}
+	}
+}
+
+func (fw *fsnotifyWatcher) handleEvent(event fsnotify.Event) {
+	if fw.monitor.shouldIgnore(event.Name) {
+		return
+	}
+
+	switch {
+	case event.Op&fsnotify.Create != 0:
+		info, err := os.Stat(event.Name)
+		if err != nil {
+			return
+		}
+
+		if info.IsDir() {
+			// addRecursive já cobre o próprio diretório e todo o conteúdo
+			// pré-existente dentro dele, não só as entradas registradas
+			// como watch.
+			if err := fw.addRecursive(event.Name); err != nil {
+				fw.monitor.logger.Log(fmt.Sprintf("Erro ao registrar novo diretório %s: %v", event.Name, err))
+			}
+			return
+		}
+
+		fw.monitor.upsertFile(FileInfo{
+			Path:    event.Name,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+			IsDir:   false,
+		})
+
+	case event.Op&fsnotify.Write != 0:
+		info, err := os.Stat(event.Name)
+		if err != nil || info.IsDir() {
+			return
+		}
+
+		fw.monitor.upsertFile(FileInfo{
+			Path:    event.Name,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+			IsDir:   false,
+		})
+
+	case event.Op&(fsnotify.Remove|fsnotify.Rename) != 0:
+		// Remover o registro é inofensivo mesmo quando event.Name não é um
+		// diretório monitorado.
+		fw.watcher.Remove(event.Name)
+		fw.monitor.removeFile(event.Name)
+	}
+}
@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thenopholo/go_file_manager/actions"
+	"github.com/thenopholo/go_file_manager/logger"
+	"github.com/thenopholo/go_file_manager/monitor"
+)
+
+const (
+	EventCreated  = "CREATED"
+	EventModified = "MODIFIED"
+	EventDeleted  = "DELETED"
+)
+
+// Engine avalia eventos do FileMonitor contra uma lista ordenada de regras e
+// dispara a ação correspondente à primeira regra que casar, respeitando o
+// cooldown configurado por regra. Implementa monitor.Handler.
+type Engine struct {
+	rules    []Rule
+	logger   *logger.Logger
+	storeDir string
+	dryRun   bool
+
+	cooldownMu sync.Mutex
+	lastRun    map[string]time.Time
+}
+
+// NewEngine carrega as regras de rulesFile e monta a engine. storeDir é
+// repassado para ações de backup (que usam o content store de chunker), e
+// dryRun faz toda ação disparada apenas logar o que faria.
+func NewEngine(rulesFile, storeDir string, dryRun bool, log *logger.Logger) (*Engine, error) {
+	loaded, err := Load(rulesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Engine{
+		rules:    loaded,
+		logger:   log,
+		storeDir: storeDir,
+		dryRun:   dryRun,
+		lastRun:  make(map[string]time.Time),
+	}, nil
+}
+
+func (e *Engine) OnCreate(info monitor.FileInfo) {
+	e.evaluate(EventCreated, info)
+}
+
+func (e *Engine) OnModify(old, new monitor.FileInfo) {
+	e.evaluate(EventModified, new)
+}
+
+func (e *Engine) OnDelete(info monitor.FileInfo) {
+	e.evaluate(EventDeleted, info)
+}
+
+func (e *Engine) evaluate(event string, info monitor.FileInfo) {
+	if info.IsDir {
+		return
+	}
+
+	for i, rule := range e.rules {
+		if !matches(rule.Match, event, info) {
+			continue
+		}
+
+		// A primeira regra que casar decide o destino do evento: regras
+		// posteriores não são avaliadas, mesmo que esta esteja em cooldown
+		// ou sua ação seja inválida, para evitar que uma regra específica
+		// seguida de uma regra "catch-all" dispare as duas ações para o
+		// mesmo evento.
+		key := fmt.Sprintf("%d:%s", i, info.Path)
+		if e.onCooldown(key, rule.Action.Cooldown) {
+			e.logger.Debugf("action", "regra %d em cooldown para %s, ignorando", i, info.Path)
+			break
+		}
+
+		action, err := e.buildAction(rule.Action)
+		if err != nil {
+			e.logger.Errorf("regra %d inválida: %v", i, err)
+			break
+		}
+
+		if err := action.Execute(info.Path); err != nil {
+			e.logger.Errorf("erro ao executar regra %d para %s: %v", i, info.Path, err)
+		}
+
+		break
+	}
+}
+
+func matches(m Match, event string, info monitor.FileInfo) bool {
+	if len(m.EventIn) > 0 && !contains(m.EventIn, event) {
+		return false
+	}
+
+	if m.Glob != "" {
+		ok, err := filepath.Match(m.Glob, filepath.Base(info.Path))
+		if err != nil || !ok {
+			return false
+		}
+	}
+
+	if len(m.ExtIn) > 0 && !contains(m.ExtIn, filepath.Ext(info.Path)) {
+		return false
+	}
+
+	if m.MinSize > 0 && info.Size < m.MinSize {
+		return false
+	}
+
+	if m.MaxSize > 0 && info.Size > m.MaxSize {
+		return false
+	}
+
+	if m.MinAge != "" {
+		minAge, err := time.ParseDuration(m.MinAge)
+		if err == nil && time.Since(info.ModTime) < minAge {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Engine) onCooldown(key, cooldown string) bool {
+	if cooldown == "" {
+		return false
+	}
+
+	window, err := time.ParseDuration(cooldown)
+	if err != nil {
+		return false
+	}
+
+	e.cooldownMu.Lock()
+	defer e.cooldownMu.Unlock()
+
+	if last, exists := e.lastRun[key]; exists && time.Since(last) < window {
+		return true
+	}
+
+	e.lastRun[key] = time.Now()
+	return false
+}
+
+func (e *Engine) buildAction(spec ActionSpec) (*actions.Action, error) {
+	var action *actions.Action
+
+	switch actions.ActionType(spec.Type) {
+	case actions.ActionBackup:
+		action = actions.NewBackupAction(spec.Target, e.storeDir, e.logger)
+	case actions.ActionArchive:
+		action = actions.NewArchiveAction(spec.Target, e.logger)
+	case actions.ActionExecute:
+		action = actions.NewExecuteAction(spec.Command, spec.Args, e.logger)
+	case actions.ActionMove:
+		action = actions.NewMoveAction(spec.Target, e.logger)
+	case actions.ActionCompress:
+		action = actions.NewCompressAction(spec.Target, e.logger)
+	case actions.ActionDelete:
+		action = actions.NewDeleteAction(e.logger)
+	default:
+		return nil, fmt.Errorf("tipo de ação desconhecido: %s", spec.Type)
+	}
+
+	action.DryRun = e.dryRun
+	return action, nil
+}
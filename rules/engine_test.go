@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thenopholo/go_file_manager/actions"
+	"github.com/thenopholo/go_file_manager/config"
+	"github.com/thenopholo/go_file_manager/logger"
+	"github.com/thenopholo/go_file_manager/monitor"
+)
+
+func TestMatchesGlobExtAndSize(t *testing.T) {
+	info := monitor.FileInfo{Path: "/tmp/backup/report.log", Size: 2048}
+
+	cases := []struct {
+		name  string
+		match Match
+		want  bool
+	}{
+		{"glob casa", Match{Glob: "*.log"}, true},
+		{"glob não casa", Match{Glob: "*.txt"}, false},
+		{"extensão na lista", Match{ExtIn: []string{".log"}}, true},
+		{"extensão fora da lista", Match{ExtIn: []string{".txt"}}, false},
+		{"tamanho mínimo respeitado", Match{MinSize: 1024}, true},
+		{"tamanho mínimo não atingido", Match{MinSize: 4096}, false},
+		{"tamanho máximo excedido", Match{MaxSize: 1024}, false},
+		{"evento fora da lista", Match{EventIn: []string{EventDeleted}}, false},
+		{"evento na lista", Match{EventIn: []string{EventModified}}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := matches(tc.match, EventModified, info)
+			if got != tc.want {
+				t.Errorf("matches() = %v, queria %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOnCooldownBlocksWithinWindow(t *testing.T) {
+	e := &Engine{lastRun: make(map[string]time.Time)}
+
+	if e.onCooldown("0:/tmp/a.log", "1h") {
+		t.Fatal("primeira chamada não deveria estar em cooldown")
+	}
+
+	if !e.onCooldown("0:/tmp/a.log", "1h") {
+		t.Fatal("segunda chamada dentro da janela deveria estar em cooldown")
+	}
+
+	if e.onCooldown("0:/tmp/b.log", "1h") {
+		t.Fatal("chave diferente não deveria compartilhar cooldown")
+	}
+}
+
+func TestOnCooldownDisabledWhenEmpty(t *testing.T) {
+	e := &Engine{lastRun: make(map[string]time.Time)}
+
+	if e.onCooldown("0:/tmp/a.log", "") {
+		t.Fatal("cooldown vazio nunca deveria bloquear")
+	}
+	if e.onCooldown("0:/tmp/a.log", "") {
+		t.Fatal("cooldown vazio nunca deveria bloquear, mesmo repetido")
+	}
+}
+
+// TestEvaluateStopsAtFirstMatchingRule garante o contrato documentado em
+// Engine: só a ação da primeira regra que casar deve ser disparada, mesmo
+// que uma regra catch-all posterior também case com o mesmo evento.
+func TestEvaluateStopsAtFirstMatchingRule(t *testing.T) {
+	dir := t.TempDir()
+	logDir := filepath.Join(dir, "logs")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		t.Fatalf("falha ao criar logDir: %v", err)
+	}
+
+	log, err := logger.NewLogger(config.Config{LogDir: logDir, MaxLogSize: 1024 * 1024 * 10})
+	if err != nil {
+		t.Fatalf("falha ao criar logger: %v", err)
+	}
+
+	path := filepath.Join(dir, "report.log")
+	if err := os.WriteFile(path, []byte("conteudo"), 0o644); err != nil {
+		t.Fatalf("falha ao escrever arquivo de teste: %v", err)
+	}
+
+	firstDest := filepath.Join(dir, "first")
+	secondDest := filepath.Join(dir, "second")
+
+	e := &Engine{
+		logger:  log,
+		lastRun: make(map[string]time.Time),
+		rules: []Rule{
+			{Match: Match{Glob: "*.log"}, Action: ActionSpec{Type: string(actions.ActionMove), Target: firstDest}},
+			{Match: Match{}, Action: ActionSpec{Type: string(actions.ActionMove), Target: secondDest}},
+		},
+	}
+
+	e.evaluate(EventModified, monitor.FileInfo{Path: path, Size: 8})
+
+	if _, err := os.Stat(filepath.Join(firstDest, "report.log")); err != nil {
+		t.Errorf("esperava que a primeira regra tivesse movido o arquivo: %v", err)
+	}
+
+	if _, err := os.Stat(secondDest); err == nil {
+		t.Error("a segunda regra (catch-all) não deveria ter sido avaliada")
+	}
+}
@@ -0,0 +1,37 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load lê um arquivo de regras em YAML ou JSON, escolhendo o decodificador
+// pela extensão do arquivo (".yaml"/".yml" para YAML, qualquer outra coisa
+// para JSON).
+func Load(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo de regras %s: %w", path, err)
+	}
+
+	var rules []Rule
+
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &rules); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar regras YAML de %s: %w", path, err)
+		}
+	default:
+		if err := json.Unmarshal(data, &rules); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar regras JSON de %s: %w", path, err)
+		}
+	}
+
+	return rules, nil
+}
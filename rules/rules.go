@@ -0,0 +1,28 @@
+// Package rules implementa a engine de automação declarativa que faltava
+// entre monitor e actions: um arquivo de regras (YAML ou JSON) mapeia
+// padrões de arquivo e eventos para ações, e rules.Engine se registra como
+// monitor.Handler para avaliar cada evento detectado contra a lista de
+// regras, na ordem em que aparecem no arquivo.
+package rules
+
+type Match struct {
+	Glob    string   `yaml:"glob" json:"glob"`
+	MinAge  string   `yaml:"min_age" json:"min_age"`
+	MinSize int64    `yaml:"min_size" json:"min_size"`
+	MaxSize int64    `yaml:"max_size" json:"max_size"`
+	ExtIn   []string `yaml:"ext_in" json:"ext_in"`
+	EventIn []string `yaml:"event_in" json:"event_in"`
+}
+
+type ActionSpec struct {
+	Type     string   `yaml:"type" json:"type"`
+	Target   string   `yaml:"target" json:"target"`
+	Command  string   `yaml:"command" json:"command"`
+	Args     []string `yaml:"args" json:"args"`
+	Cooldown string   `yaml:"cooldown" json:"cooldown"`
+}
+
+type Rule struct {
+	Match  Match      `yaml:"match" json:"match"`
+	Action ActionSpec `yaml:"action" json:"action"`
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package stats
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// ownerOf retorna o UID dono de path como string, usando syscall.Stat_t.
+// Erros de stat (arquivo removido entre a varredura e a geração de
+// estatísticas, por exemplo) caem no grupo "desconhecido" em vez de abortar
+// a geração inteira das estatísticas.
+func ownerOf(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "desconhecido"
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "desconhecido"
+	}
+
+	return fmt.Sprintf("%d", stat.Uid)
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/thenopholo/go_file_manager/config"
+	"github.com/thenopholo/go_file_manager/metrics"
 	"github.com/thenopholo/go_file_manager/monitor"
 )
 
@@ -17,6 +19,9 @@ type Stats struct {
 	TotalSize      int64               `json:"total_size"`
 	TotalSizeHuman string              `json:"total_size_human"`
 	ByExtentions   map[string]ExtStats `json:"by_extention"`
+	ByDirectory    map[string]DirStats `json:"by_directory"`
+	ByOwner        map[string]DirStats `json:"by_owner"`
+	ByAge          map[string]DirStats `json:"by_age"`
 }
 
 type ExtStats struct {
@@ -24,19 +29,104 @@ type ExtStats struct {
 	Size  int64 `json:"size_bytes"`
 }
 
-func GenerateStats(monitor *monitor.FileMonitor, cfg config.Config) (*Stats, error) {
+// DirStats é reaproveitada para as quebras por diretório, dono e faixa de
+// idade: todas têm a mesma forma (contagem + tamanho total).
+type DirStats struct {
+	Count int   `json:"count"`
+	Size  int64 `json:"size_bytes"`
+}
+
+func GenerateStats(fileMonitor *monitor.FileMonitor, cfg config.Config) (*Stats, error) {
+	files := fileMonitor.Files()
+	now := time.Now()
+
 	stats := &Stats{
-		Timestamp:    time.Now(),
-		FileCount:    monitor.GetFileCount(),
-		TotalSize:    monitor.GetTotalSize(),
+		Timestamp:    now,
 		ByExtentions: make(map[string]ExtStats),
+		ByDirectory:  make(map[string]DirStats),
+		ByOwner:      make(map[string]DirStats),
+		ByAge:        make(map[string]DirStats),
+	}
+
+	for _, info := range files {
+		if info.IsDir {
+			continue
+		}
+
+		stats.FileCount++
+		stats.TotalSize += info.Size
+
+		ext := filepath.Ext(info.Path)
+		if ext == "" {
+			ext = "(sem extensão)"
+		}
+		extEntry := stats.ByExtentions[ext]
+		extEntry.Count++
+		extEntry.Size += info.Size
+		stats.ByExtentions[ext] = extEntry
+
+		dir := topLevelDir(cfg.WatchDir, info.Path)
+		dirEntry := stats.ByDirectory[dir]
+		dirEntry.Count++
+		dirEntry.Size += info.Size
+		stats.ByDirectory[dir] = dirEntry
+
+		owner := ownerOf(info.Path)
+		ownerEntry := stats.ByOwner[owner]
+		ownerEntry.Count++
+		ownerEntry.Size += info.Size
+		stats.ByOwner[owner] = ownerEntry
+
+		bucket := ageBucket(now, info.ModTime)
+		ageEntry := stats.ByAge[bucket]
+		ageEntry.Count++
+		ageEntry.Size += info.Size
+		stats.ByAge[bucket] = ageEntry
 	}
 
 	stats.TotalSizeHuman = formatSize(stats.TotalSize)
 
+	metrics.FilesGauge.Set(float64(stats.FileCount))
+	metrics.BytesGauge.Set(float64(stats.TotalSize))
+
 	return stats, nil
 }
 
+// topLevelDir retorna o primeiro segmento do caminho de path relativo a
+// root, usado para agrupar arquivos pelo subdiretório de topo dentro da
+// WatchDir. Arquivos diretamente na raiz caem no grupo ".".
+func topLevelDir(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return "."
+	}
+
+	if idx := strings.IndexRune(rel, filepath.Separator); idx >= 0 {
+		return rel[:idx]
+	}
+
+	return "."
+}
+
+// ageBucket classifica o tempo decorrido desde a última modificação em uma
+// das faixas usadas pela quebra "by_age".
+func ageBucket(now, modTime time.Time) string {
+	age := now.Sub(modTime)
+
+	switch {
+	case age < time.Hour:
+		return "<1h"
+	case age < 24*time.Hour:
+		return "<24h"
+	case age < 7*24*time.Hour:
+		return "<7d"
+	case age < 30*24*time.Hour:
+		return "<30d"
+	default:
+		return "older"
+	}
+}
+
 func SaveStatsToFile(stats *Stats, cfg config.Config) error {
 	statsDir := filepath.Join(cfg.LogDir, "stats")
 